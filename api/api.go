@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+// API is the Podman REST client shared by the driver's task handles. This
+// file declares the long-lived collector/broadcaster state the streaming
+// stats and events paths attach to the client; the request/transport
+// plumbing lives alongside the rest of the client setup.
+type API struct {
+	logger hclog.Logger
+
+	containerStatsCollectInterval    time.Duration
+	isContainerStatsCollectorRunning bool
+	containerStatsBroadcaster        *containerStatsBroadcaster
+
+	isContainerEventsCollectorRunning bool
+	containerEventsBroadcaster        *containerEventsBroadcaster
+
+	// statsCollectorBackend selects the StatsCollector implementation used
+	// by ContainerStatsStream (StatsCollectorHTTP or StatsCollectorCgroupV2).
+	// It is set from the driver's `stats_collector` plugin config option.
+	statsCollectorBackend string
+}
+
+// GetStatsBroadcaster returns the active container stats broadcaster, or
+// nil if ContainerStatsStream has not been started yet.
+func (c *API) GetStatsBroadcaster() StatsBroadcaster {
+	if c.containerStatsBroadcaster == nil {
+		return nil
+	}
+	return c.containerStatsBroadcaster
+}
+
+// GetEventsBroadcaster returns the active container events broadcaster, or
+// nil if ContainerEventsStream has not been started yet.
+func (c *API) GetEventsBroadcaster() EventsBroadcaster {
+	if c.containerEventsBroadcaster == nil {
+		return nil
+	}
+	return c.containerEventsBroadcaster
+}
+
+// SetStatsCollectorBackend sets the StatsCollector backend
+// ContainerStatsStream will use. Intended to be called once, from the
+// driver's SetConfig, with the value of the `stats_collector` plugin
+// config attribute.
+func (c *API) SetStatsCollectorBackend(backend string) {
+	c.statsCollectorBackend = backend
+}
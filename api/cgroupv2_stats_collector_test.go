@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCgroupFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+	return path
+}
+
+func TestReadCgroupKeyValueFile(t *testing.T) {
+	dir := t.TempDir()
+	path := writeCgroupFile(t, dir, "cpu.stat", "usage_usec 123456\nuser_usec 100000\nsystem_usec 23456\n")
+
+	values, err := readCgroupKeyValueFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]uint64{"usage_usec": 123456, "user_usec": 100000, "system_usec": 23456}
+	for k, v := range want {
+		if values[k] != v {
+			t.Errorf("values[%q] = %d, want %d", k, values[k], v)
+		}
+	}
+}
+
+func TestReadCgroupKeyValueFileMalformedLines(t *testing.T) {
+	dir := t.TempDir()
+	path := writeCgroupFile(t, dir, "memory.events", "low 0\noom_kill not-a-number\nhigh 3\n")
+
+	values, err := readCgroupKeyValueFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if values["low"] != 0 {
+		t.Errorf("low = %d, want 0", values["low"])
+	}
+	if _, ok := values["oom_kill"]; ok {
+		t.Errorf("expected malformed oom_kill line to be skipped")
+	}
+	if values["high"] != 3 {
+		t.Errorf("high = %d, want 3", values["high"])
+	}
+}
+
+func TestReadCgroupEventCount(t *testing.T) {
+	dir := t.TempDir()
+	path := writeCgroupFile(t, dir, "memory.events", "low 0\nhigh 0\nmax 0\noom 2\noom_kill 2\n")
+
+	count, err := readCgroupEventCount(path, "oom_kill")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("got %d, want 2", count)
+	}
+
+	count, err = readCgroupEventCount(path, "missing_key")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("got %d, want 0 for missing key", count)
+	}
+}
+
+func TestReadCgroupIOStat(t *testing.T) {
+	dir := t.TempDir()
+	contents := "8:0 rbytes=1000 wbytes=2000 rios=10 wios=20\n8:16 rbytes=500 wbytes=250 rios=5 wios=3\n"
+	writeCgroupFile(t, dir, "io.stat", contents)
+
+	netInput, netOutput, blockInput, blockOutput, err := readCgroupIOStat(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if netInput != 0 || netOutput != 0 {
+		t.Errorf("expected zero network counters from cgroup io.stat, got rx=%d tx=%d", netInput, netOutput)
+	}
+	if blockInput != 1500 {
+		t.Errorf("blockInput = %d, want 1500", blockInput)
+	}
+	if blockOutput != 2250 {
+		t.Errorf("blockOutput = %d, want 2250", blockOutput)
+	}
+}
+
+func TestReadCgroupIOStatEmpty(t *testing.T) {
+	dir := t.TempDir()
+	writeCgroupFile(t, dir, "io.stat", "")
+
+	_, _, blockInput, blockOutput, err := readCgroupIOStat(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blockInput != 0 || blockOutput != 0 {
+		t.Errorf("expected zero counters for empty io.stat, got read=%d write=%d", blockInput, blockOutput)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ContainerEventActor carries the subject of an Event, along with any
+// action-specific metadata Podman attaches (e.g. "exitCode" on a "die"
+// event).
+type ContainerEventActor struct {
+	ID         string            `json:"ID"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+// ContainerEvent is a single frame decoded from Podman's
+// /libpod/events?stream=true NDJSON stream, scoped to container events
+// (Type == "container").
+type ContainerEvent struct {
+	Type   string              `json:"Type"`
+	Action string              `json:"Action"`
+	Actor  ContainerEventActor `json:"Actor"`
+	Time   int64               `json:"time"`
+}
+
+type EventsBroadcaster interface {
+	Subscribe() (<-chan *ContainerEvent, <-chan error)
+	CancelSubscription(<-chan *ContainerEvent, <-chan error)
+}
+
+type containerEventsBroadcaster struct {
+	eventsChan           <-chan *ContainerEvent
+	errEventsChan        <-chan error
+	eventsListeners      []chan *ContainerEvent
+	errListeners         []chan error
+	addEventsListener    chan chan *ContainerEvent
+	addErrListener       chan chan error
+	removeEventsListener chan (<-chan *ContainerEvent)
+	removeErrListener    chan (<-chan error)
+}
+
+func NewContainerEventsBroadcaster(ctx context.Context, eventsChan <-chan *ContainerEvent, errEventsChan <-chan error) *containerEventsBroadcaster {
+	broadcaster := &containerEventsBroadcaster{
+		eventsChan:           eventsChan,
+		errEventsChan:        errEventsChan,
+		eventsListeners:      make([]chan *ContainerEvent, 0),
+		errListeners:         make([]chan error, 0),
+		addEventsListener:    make(chan chan *ContainerEvent),
+		addErrListener:       make(chan chan error),
+		removeEventsListener: make(chan (<-chan *ContainerEvent)),
+		removeErrListener:    make(chan (<-chan error)),
+	}
+
+	go broadcaster.serve(ctx)
+	return broadcaster
+}
+
+func (c *containerEventsBroadcaster) Subscribe() (<-chan *ContainerEvent, <-chan error) {
+	newEventsListener := make(chan *ContainerEvent)
+	newErrListener := make(chan error)
+	c.addEventsListener <- newEventsListener
+	c.addErrListener <- newErrListener
+	return newEventsListener, newErrListener
+}
+
+func (c *containerEventsBroadcaster) CancelSubscription(ch <-chan *ContainerEvent, errCh <-chan error) {
+	c.removeEventsListener <- ch
+	c.removeErrListener <- errCh
+}
+
+func (c *containerEventsBroadcaster) serve(ctx context.Context) {
+	defer func() {
+		for _, eventsListener := range c.eventsListeners {
+			if eventsListener != nil {
+				close(eventsListener)
+			}
+		}
+		for _, errListener := range c.errListeners {
+			if errListener != nil {
+				close(errListener)
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case newEventsListener := <-c.addEventsListener:
+			c.eventsListeners = append(c.eventsListeners, newEventsListener)
+		case newErrListener := <-c.addErrListener:
+			c.errListeners = append(c.errListeners, newErrListener)
+		case removeEventsListener := <-c.removeEventsListener:
+			for i, listener := range c.eventsListeners {
+				if listener == removeEventsListener {
+					c.eventsListeners = append(c.eventsListeners[:i], c.eventsListeners[i+1:]...)
+					close(listener)
+					break
+				}
+			}
+		case removeErrListener := <-c.removeErrListener:
+			for i, listener := range c.errListeners {
+				if listener == removeErrListener {
+					c.errListeners = append(c.errListeners[:i], c.errListeners[i+1:]...)
+					close(listener)
+					break
+				}
+			}
+		case event := <-c.eventsChan:
+			for _, listener := range c.eventsListeners {
+				select {
+				case listener <- event:
+				default:
+				}
+			}
+		case err := <-c.errEventsChan:
+			for _, listener := range c.errListeners {
+				select {
+				case listener <- err:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// containerEventsReconnectBackoff bounds the delay between attempts to
+// re-establish the streaming events connection after it is lost.
+const containerEventsReconnectBackoff = 30 * time.Second
+
+// ContainerEventsStream subscribes to Podman's libpod events stream and
+// fans the decoded events out through an EventsBroadcaster. Consumers
+// (e.g. the task handle's event watcher) filter by Actor.ID to find the
+// events relevant to the container they own, mirroring how
+// ContainerStatsStream hands every container's stats to every
+// subscriber.
+func (c *API) ContainerEventsStream(ctx context.Context) {
+	if c.isContainerEventsCollectorRunning {
+		c.logger.Debug("Container events collector is already running, skipping...")
+		return
+	}
+	c.isContainerEventsCollectorRunning = true
+
+	eventsChan := make(chan *ContainerEvent)
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer close(eventsChan)
+		defer close(errChan)
+
+		backoff := time.Second
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			if err := c.streamContainerEvents(ctx, eventsChan); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error("Container events stream interrupted, reconnecting", "error", err, "backoff", backoff)
+				errChan <- err
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+
+				backoff *= 2
+				if backoff > containerEventsReconnectBackoff {
+					backoff = containerEventsReconnectBackoff
+				}
+				continue
+			}
+
+			return
+		}
+	}()
+
+	c.containerEventsBroadcaster = NewContainerEventsBroadcaster(ctx, eventsChan, errChan)
+}
+
+// streamContainerEvents opens a single long-lived connection to Podman's
+// events endpoint and decodes one NDJSON frame at a time, pushing
+// container events onto eventsChan. It returns nil when ctx is
+// canceled, and a non-nil error for any transport or decode failure so
+// the caller can reconnect.
+func (c *API) streamContainerEvents(ctx context.Context, eventsChan chan<- *ContainerEvent) error {
+	res, err := c.Get(ctx, "/v4.0.0/libpod/events?stream=true")
+	if err != nil {
+		return err
+	}
+	defer ignoreClose(res.Body)
+
+	go func() {
+		<-ctx.Done()
+		res.Body.Close()
+	}()
+
+	dec := json.NewDecoder(res.Body)
+	for {
+		var event ContainerEvent
+		if err := dec.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("container events stream closed by server: %w", err)
+			}
+			return err
+		}
+
+		if event.Type != "container" {
+			continue
+		}
+
+		select {
+		case eventsChan <- &event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// ContainerEventExitCode parses the "exitCode" attribute Podman attaches
+// to "die" events. It returns an error if the attribute is missing or
+// not a valid integer.
+func ContainerEventExitCode(event *ContainerEvent) (int, error) {
+	raw, ok := event.Actor.Attributes["exitCode"]
+	if !ok {
+		return 0, fmt.Errorf("die event for %s has no exitCode attribute", event.Actor.ID)
+	}
+	code, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("die event for %s has invalid exitCode %q: %w", event.Actor.ID, raw, err)
+	}
+	return code, nil
+}
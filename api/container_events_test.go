@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import "testing"
+
+func TestContainerEventExitCode(t *testing.T) {
+	cases := []struct {
+		name    string
+		event   *ContainerEvent
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "valid exit code",
+			event: &ContainerEvent{
+				Actor: ContainerEventActor{ID: "abc123", Attributes: map[string]string{"exitCode": "137"}},
+			},
+			want: 137,
+		},
+		{
+			name: "zero exit code",
+			event: &ContainerEvent{
+				Actor: ContainerEventActor{ID: "abc123", Attributes: map[string]string{"exitCode": "0"}},
+			},
+			want: 0,
+		},
+		{
+			name: "missing attribute",
+			event: &ContainerEvent{
+				Actor: ContainerEventActor{ID: "abc123", Attributes: map[string]string{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-numeric attribute",
+			event: &ContainerEvent{
+				Actor: ContainerEventActor{ID: "abc123", Attributes: map[string]string{"exitCode": "not-a-number"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ContainerEventExitCode(tc.event)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("got exit code %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+// ContainerHealthCheckLog is a single healthcheck probe result, as recorded
+// by Podman under State.Health.Log on the inspect payload.
+type ContainerHealthCheckLog struct {
+	Start    string `json:"Start"`
+	End      string `json:"End"`
+	ExitCode int    `json:"ExitCode"`
+	Output   string `json:"Output"`
+}
+
+// ContainerHealth mirrors the State.Health object Podman attaches to a
+// container's inspect payload when it has a configured healthcheck.
+type ContainerHealth struct {
+	Status        string                    `json:"Status"`
+	FailingStreak int                       `json:"FailingStreak"`
+	Log           []ContainerHealthCheckLog `json:"Log"`
+}
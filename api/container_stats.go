@@ -163,6 +163,18 @@ func (c *containerStatsBroadcaster) serve(ctx context.Context) {
 	}
 }
 
+// containerStatsReconnectBackoff bounds the delay between attempts to
+// re-establish the streaming stats connection after it is lost.
+const containerStatsReconnectBackoff = 30 * time.Second
+
+// AllContainerStats wraps the stats in a struct to match the API response.
+// This is needed to decode the JSON response from Podman since it returns
+// an object with "error" and "stats" fields.
+type AllContainerStats struct {
+	Error Error             `json:"error"`
+	Stats []*ContainerStats `json:"stats"`
+}
+
 func (c *API) ContainerStatsStream(ctx context.Context) {
 	if c.isContainerStatsCollectorRunning {
 		c.logger.Debug("Container stats collector is already running, skipping...")
@@ -170,49 +182,90 @@ func (c *API) ContainerStatsStream(ctx context.Context) {
 	}
 	c.isContainerStatsCollectorRunning = true
 
+	collector := c.statsCollector()
+	c.logger.Debug("Starting container stats collector", "backend", collector.Name())
+
 	statsChan := make(chan []*ContainerStats)
 	errChan := make(chan error, 1)
 
-	// Wrap the stats in a struct to match the API response
-	// This is needed to decode the JSON response from Podman
-	// since it returns an object with "error" and "stats" fields
-	type AllContainerStats struct {
-		Error Error             `json:"error"`
-		Stats []*ContainerStats `json:"stats"`
-	}
-
 	go func() {
-		timer := time.NewTicker(time.Duration(1) * time.Second)
+		defer close(statsChan)
+		defer close(errChan)
+
+		backoff := time.Second
 		for {
 			select {
 			case <-ctx.Done():
-				timer.Stop()
-				close(statsChan)
-				close(errChan)
 				return
-			case <-timer.C:
-				timer.Reset(c.containerStatsCollectInterval)
+			default:
 			}
 
-			res, err := c.Get(ctx, "/v3.0.0/libpod/containers/stats?stream=false")
-			if err != nil {
-				c.logger.Error("Error getting container stats", "error", err)
-				errChan <- err
-				continue
-			}
-			dec := json.NewDecoder(res.Body)
-			var stats AllContainerStats
-			if err := dec.Decode(&stats); err != nil {
-				c.logger.Error("Error decoding container stats", "error", err)
+			if err := collector.Collect(ctx, statsChan); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				c.logger.Error("Container stats collector interrupted, reconnecting", "backend", collector.Name(), "error", err, "backoff", backoff)
 				errChan <- err
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(backoff):
+				}
+
+				backoff *= 2
+				if backoff > containerStatsReconnectBackoff {
+					backoff = containerStatsReconnectBackoff
+				}
 				continue
 			}
-			select {
-			case statsChan <- stats.Stats:
-			default:
-			}
+
+			// Collect only returns nil when ctx was canceled.
+			return
 		}
 	}()
 
 	c.containerStatsBroadcaster = NewContainerStatsBroadcaster(ctx, statsChan, errChan)
 }
+
+// streamContainerStats opens a single long-lived connection to Podman's
+// streaming stats endpoint and decodes one AllContainerStats frame per
+// server-side interval tick, pushing each onto statsChan. It returns nil
+// when ctx is canceled, and a non-nil error for any transport or decode
+// failure so the caller can reconnect.
+func (c *API) streamContainerStats(ctx context.Context, statsChan chan<- []*ContainerStats) error {
+	path := fmt.Sprintf("/v3.0.0/libpod/containers/stats?stream=true&interval=%d", int(c.containerStatsCollectInterval.Seconds()))
+	res, err := c.Get(ctx, path)
+	if err != nil {
+		return err
+	}
+	defer ignoreClose(res.Body)
+
+	// Unblock the decoder as soon as the context is canceled; Decode has no
+	// context awareness of its own and would otherwise block on the socket
+	// until the server sends another frame or closes the connection.
+	go func() {
+		<-ctx.Done()
+		res.Body.Close()
+	}()
+
+	dec := json.NewDecoder(res.Body)
+	for {
+		var stats AllContainerStats
+		if err := dec.Decode(&stats); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, io.EOF) {
+				return fmt.Errorf("container stats stream closed by server: %w", err)
+			}
+			return err
+		}
+
+		select {
+		case statsChan <- stats.Stats:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package api
+
+import (
+	"context"
+)
+
+// StatsCollectorHTTP and StatsCollectorCgroupV2 are the accepted values for
+// the driver's `stats_collector` plugin config option.
+const (
+	StatsCollectorHTTP     = "api"
+	StatsCollectorCgroupV2 = "cgroupv2"
+)
+
+// StatsCollector feeds []*ContainerStats frames into ContainerStatsStream's
+// broadcaster. Collect blocks, pushing one frame per interval tick onto
+// statsChan, until ctx is canceled (in which case it returns nil) or it
+// hits an unrecoverable error, in which case ContainerStatsStream
+// reconnects by calling Collect again.
+type StatsCollector interface {
+	Name() string
+	Collect(ctx context.Context, statsChan chan<- []*ContainerStats) error
+}
+
+// statsCollector selects the StatsCollector implementation named by the
+// driver's `stats_collector` config option, defaulting to the HTTP
+// collector when unset or unrecognized.
+func (c *API) statsCollector() StatsCollector {
+	switch c.statsCollectorBackend {
+	case StatsCollectorCgroupV2:
+		return newCgroupV2StatsCollector(c)
+	case StatsCollectorHTTP, "":
+		return &httpStatsCollector{api: c}
+	default:
+		c.logger.Warn("Unknown stats_collector, falling back to the HTTP collector", "stats_collector", c.statsCollectorBackend)
+		return &httpStatsCollector{api: c}
+	}
+}
+
+// httpStatsCollector is the original collector: it reads Podman's REST API
+// streaming stats endpoint over the API socket.
+type httpStatsCollector struct {
+	api *API
+}
+
+func (h *httpStatsCollector) Name() string { return StatsCollectorHTTP }
+
+func (h *httpStatsCollector) Collect(ctx context.Context, statsChan chan<- []*ContainerStats) error {
+	return h.api.streamContainerStats(ctx, statsChan)
+}
+
+var _ StatsCollector = (*httpStatsCollector)(nil)
@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/nomad-driver-podman/api"
+	"github.com/hashicorp/nomad/plugins/base"
+)
+
+// applyStatsCollectorConfig validates the decoded `stats_collector` plugin
+// config attribute and configures the API client's StatsCollector backend
+// accordingly. Called from SetConfig below.
+func applyStatsCollectorConfig(client *api.API, statsCollector string) error {
+	switch statsCollector {
+	case "", api.StatsCollectorHTTP, api.StatsCollectorCgroupV2:
+		client.SetStatsCollectorBackend(statsCollector)
+		return nil
+	default:
+		return fmt.Errorf("invalid stats_collector %q: must be %q or %q", statsCollector, api.StatsCollectorHTTP, api.StatsCollectorCgroupV2)
+	}
+}
+
+// statsCollectorPluginConfig is the subset of the driver's plugin config
+// stanza this file cares about.
+type statsCollectorPluginConfig struct {
+	StatsCollector string `codec:"stats_collector"`
+}
+
+// SetConfig decodes the `stats_collector` plugin config attribute and
+// applies it to the driver's API client, so the driver's plugin config
+// stanza can actually select the StatsCollector backend.
+func (d *Driver) SetConfig(cfg *base.Config) error {
+	var pluginConfig statsCollectorPluginConfig
+	if len(cfg.PluginConfig) != 0 {
+		if err := base.MsgPackDecode(cfg.PluginConfig, &pluginConfig); err != nil {
+			return err
+		}
+	}
+	return applyStatsCollectorConfig(d.podmanClient, pluginConfig.StatsCollector)
+}
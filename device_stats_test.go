@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateBytesPerSecond(t *testing.T) {
+	cases := []struct {
+		name    string
+		cur     uint64
+		prev    uint64
+		elapsed time.Duration
+		want    float64
+	}{
+		{name: "normal delta over one second", cur: 2000, prev: 1000, elapsed: time.Second, want: 1000},
+		{name: "normal delta over half a second", cur: 1500, prev: 1000, elapsed: 500 * time.Millisecond, want: 1000},
+		{name: "zero elapsed", cur: 2000, prev: 1000, elapsed: 0, want: 0},
+		{name: "negative elapsed", cur: 2000, prev: 1000, elapsed: -time.Second, want: 0},
+		{name: "counter went backwards", cur: 500, prev: 1000, elapsed: time.Second, want: 0},
+		{name: "no change", cur: 1000, prev: 1000, elapsed: time.Second, want: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := rateBytesPerSecond(tc.cur, tc.prev, tc.elapsed)
+			if got != tc.want {
+				t.Errorf("rateBytesPerSecond(%d, %d, %s) = %v, want %v", tc.cur, tc.prev, tc.elapsed, got, tc.want)
+			}
+		})
+	}
+}
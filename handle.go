@@ -5,9 +5,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"sync"
 	"syscall"
 	"time"
@@ -15,7 +18,9 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad-driver-podman/api"
 	"github.com/hashicorp/nomad/client/lib/cpustats"
+	"github.com/hashicorp/nomad/plugins/device"
 	"github.com/hashicorp/nomad/plugins/drivers"
+	"github.com/hashicorp/nomad/plugins/shared/structs"
 )
 
 var (
@@ -23,6 +28,15 @@ var (
 	measuredMemStats = []string{"Usage", "Max Usage"}
 )
 
+// statsDeviceVendor/statsDeviceType identify the synthetic "device" used to
+// carry network, block I/O and PIDs counters through
+// drivers.ResourceUsage.DeviceStats, since Nomad's CpuStats/MemoryStats
+// have no fields for them.
+const (
+	statsDeviceVendor = "podman"
+	statsDeviceType   = "io"
+)
+
 // TaskHandle is the podman specific handle for exactly one container
 type TaskHandle struct {
 	containerID  string
@@ -46,7 +60,21 @@ type TaskHandle struct {
 	completedAt time.Time
 	exitResult  *drivers.ExitResult
 
-	containerStats        api.ContainerStats
+	// journalCursor is the last journald cursor consumed by
+	// runJournaldLogStreamer, persisted so a reconnect resumes exactly
+	// where it left off instead of losing or duplicating records.
+	journalCursor string
+
+	containerStats     api.ContainerStats
+	prevContainerStats api.ContainerStats
+	// containerStatsSampledAt/prevContainerStatsSampledAt record when
+	// containerStats/prevContainerStats actually arrived, so
+	// deviceStatsLocked can derive rates from the real elapsed time
+	// between samples instead of assuming it matches the stats
+	// emitter's own interval.
+	containerStatsSampledAt     time.Time
+	prevContainerStatsSampledAt time.Time
+	health                      api.ContainerHealth
 	removeContainerOnExit bool
 	logStreamer           bool
 }
@@ -55,6 +83,14 @@ func (h *TaskHandle) taskStatus() *drivers.TaskStatus {
 	h.stateLock.RLock()
 	defer h.stateLock.RUnlock()
 
+	attrs := map[string]string{
+		"health.status":         h.health.Status,
+		"health.failing_streak": fmt.Sprintf("%d", h.health.FailingStreak),
+	}
+	if len(h.health.Log) > 0 {
+		attrs["health.last_output"] = h.health.Log[len(h.health.Log)-1].Output
+	}
+
 	return &drivers.TaskStatus{
 		ID:               h.taskConfig.ID,
 		Name:             h.taskConfig.Name,
@@ -62,9 +98,7 @@ func (h *TaskHandle) taskStatus() *drivers.TaskStatus {
 		StartedAt:        h.startedAt,
 		CompletedAt:      h.completedAt,
 		ExitResult:       h.exitResult,
-		DriverAttributes: map[string]string{
-			// we do not need custom attributes yet
-		},
+		DriverAttributes: attrs,
 	}
 }
 
@@ -132,6 +166,8 @@ func (h *TaskHandle) runStatsEmitter(ctx context.Context, statsChannel chan *dri
 			RSS:      h.containerStats.MemUsage,
 			Measured: measuredMemStats,
 		}
+
+		ds := h.deviceStatsLocked()
 		h.stateLock.Unlock()
 
 		// update usage
@@ -139,6 +175,7 @@ func (h *TaskHandle) runStatsEmitter(ctx context.Context, statsChannel chan *dri
 			ResourceUsage: &drivers.ResourceUsage{
 				CpuStats:    cs,
 				MemoryStats: ms,
+				DeviceStats: ds,
 			},
 			Timestamp: t.UTC().UnixNano(),
 		}
@@ -147,6 +184,60 @@ func (h *TaskHandle) runStatsEmitter(ctx context.Context, statsChannel chan *dri
 		statsChannel <- &usage
 	}
 }
+
+// deviceStatsLocked translates the monotonic network/block-io counters
+// Podman reports into per-second rates, plus a PIDs gauge, and packages
+// them as a DeviceGroupStats entry so they flow through Nomad's metrics
+// subsystem alongside CpuStats/MemoryStats. Rates are derived from the
+// actual elapsed time between the two samples, not from the stats
+// emitter's own interval, since the samples themselves arrive on the
+// Podman-side collector's independently configured interval. Callers must
+// hold stateLock.
+func (h *TaskHandle) deviceStatsLocked() []*device.DeviceGroupStats {
+	if h.prevContainerStats.ContainerID == "" || h.prevContainerStatsSampledAt.IsZero() {
+		// first sample since start: no baseline to derive a rate from
+		return nil
+	}
+	elapsed := h.containerStatsSampledAt.Sub(h.prevContainerStatsSampledAt)
+
+	instances := map[string]*structs.StatValue{
+		"net_rx_bytes_per_sec":    statValue(rateBytesPerSecond(h.containerStats.NetInput, h.prevContainerStats.NetInput, elapsed)),
+		"net_tx_bytes_per_sec":    statValue(rateBytesPerSecond(h.containerStats.NetOutput, h.prevContainerStats.NetOutput, elapsed)),
+		"blk_read_bytes_per_sec":  statValue(rateBytesPerSecond(h.containerStats.BlockInput, h.prevContainerStats.BlockInput, elapsed)),
+		"blk_write_bytes_per_sec": statValue(rateBytesPerSecond(h.containerStats.BlockOutput, h.prevContainerStats.BlockOutput, elapsed)),
+		"pids":                    statValue(float64(h.containerStats.PIDs)),
+	}
+
+	stats := make(map[string]*device.DeviceStats, len(instances))
+	for name, value := range instances {
+		stats[name] = &device.DeviceStats{Summary: value}
+	}
+
+	return []*device.DeviceGroupStats{
+		{
+			Vendor:        statsDeviceVendor,
+			Type:          statsDeviceType,
+			Name:          h.containerID,
+			InstanceStats: stats,
+		},
+	}
+}
+
+func statValue(v float64) *structs.StatValue {
+	return &structs.StatValue{FloatNumeratorVal: &v}
+}
+
+// rateBytesPerSecond derives a per-second rate from two monotonic counter
+// samples and the wall-clock time elapsed between them. It returns 0 if
+// the counter went backwards (e.g. it was reset) or no time has elapsed.
+func rateBytesPerSecond(cur, prev uint64, elapsed time.Duration) float64 {
+	seconds := elapsed.Seconds()
+	if seconds <= 0 || cur < prev {
+		return 0
+	}
+	return float64(cur-prev) / seconds
+}
+
 func (h *TaskHandle) runLogStreamer(ctx context.Context) {
 	stdout, err := os.OpenFile(h.taskConfig.StdoutPath, os.O_WRONLY|syscall.O_NONBLOCK, 0600)
 	if err != nil {
@@ -161,6 +252,15 @@ func (h *TaskHandle) runLogStreamer(ctx context.Context) {
 	}
 	defer stderr.Close()
 
+	// journald is a first-class Podman log driver; tailing the log file
+	// the k8s-file/json-file path reads from misses records and can
+	// duplicate them on reconnect, so read directly from the journal
+	// instead.
+	if h.containerLogDriver(ctx) == "journald" {
+		h.runJournaldLogStreamer(ctx, stdout, stderr)
+		return
+	}
+
 	init := true
 	since := h.logPointer
 	for {
@@ -190,6 +290,138 @@ func (h *TaskHandle) runLogStreamer(ctx context.Context) {
 
 }
 
+// containerLogDriver returns the container's configured log driver (e.g.
+// "journald", "k8s-file", "json-file", "none"), or "" if it could not be
+// determined, in which case callers should fall back to the HTTP log
+// streaming path.
+func (h *TaskHandle) containerLogDriver(ctx context.Context) string {
+	inspectData, err := h.podmanClient.ContainerInspect(ctx, h.containerID)
+	if err != nil {
+		h.logger.Warn("Unable to inspect container for log driver, falling back to HTTP log streaming", "container", h.containerID, "error", err)
+		return ""
+	}
+	return inspectData.HostConfig.LogConfig.Type
+}
+
+// journalEntry is the subset of a `journalctl --output=json` record we
+// care about: the message payload, its stream priority (used to route it
+// to stdout vs stderr), and the cursor identifying its position in the
+// journal.
+type journalEntry struct {
+	Message  string `json:"MESSAGE"`
+	Priority string `json:"PRIORITY"`
+	Cursor   string `json:"__CURSOR"`
+}
+
+// journalStderrPriority is the syslog priority ("err") Podman's conmon
+// tags stderr records with when writing to journald.
+const journalStderrPriority = "3"
+
+// runJournaldLogStreamer tails the container's journald entries via
+// journalctl, resuming from the last persisted cursor on reconnect so
+// records are neither lost nor duplicated.
+func (h *TaskHandle) runJournaldLogStreamer(ctx context.Context, stdout, stderr *os.File) {
+	h.logger.Debug("Container uses the journald log driver, tailing via journalctl", "container", h.containerID)
+
+	h.stateLock.RLock()
+	cursor := h.journalCursor
+	startedAt := h.startedAt
+	h.stateLock.RUnlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		args := []string{fmt.Sprintf("CONTAINER_ID=%s", h.containerID), "--output=json", "--follow"}
+		switch {
+		case cursor != "":
+			args = append(args, "--after-cursor="+cursor)
+		default:
+			// No cursor yet: this is the first connection for this task,
+			// so start from the task's launch time rather than "now" to
+			// avoid dropping lines emitted before the watcher was
+			// scheduled.
+			args = append(args, "--since="+startedAt.Format("2006-01-02 15:04:05"))
+		}
+
+		cmd := exec.CommandContext(ctx, "journalctl", args...)
+		journalOut, err := cmd.StdoutPipe()
+		if err != nil {
+			h.logger.Warn("Unable to open journalctl stdout pipe", "error", err)
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			h.logger.Warn("Unable to start journalctl", "error", err)
+			return
+		}
+
+		var pumpErr error
+		cursor, pumpErr = h.pumpJournalEntries(journalOut, stdout, stderr, cursor)
+		_ = cmd.Wait()
+
+		h.stateLock.Lock()
+		h.journalCursor = cursor
+		h.stateLock.Unlock()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if pumpErr != nil {
+			h.logger.Warn("journald log stream was interrupted, reconnecting", "error", pumpErr, "cursor", cursor)
+		}
+
+		// throttle reconnects
+		time.Sleep(2 * time.Second)
+	}
+}
+
+// pumpJournalEntries decodes NDJSON journalctl records from r and forwards
+// each MESSAGE to stdout or stderr based on its PRIORITY, returning the
+// cursor of the last record successfully forwarded.
+func (h *TaskHandle) pumpJournalEntries(r io.Reader, stdout, stderr io.Writer, cursor string) (string, error) {
+	dec := json.NewDecoder(r)
+	for {
+		var entry journalEntry
+		if err := dec.Decode(&entry); err != nil {
+			if errors.Is(err, io.EOF) {
+				return cursor, nil
+			}
+			return cursor, err
+		}
+
+		w := stdout
+		if entry.Priority == journalStderrPriority {
+			w = stderr
+		}
+		if _, err := fmt.Fprintln(w, entry.Message); err != nil {
+			return cursor, err
+		}
+
+		cursor = entry.Cursor
+	}
+}
+
+// recordExit stores the final exit state for the task under stateLock and
+// marks it as exited.
+func (h *TaskHandle) recordExit(exitCode int, oomKilled bool, completedAt time.Time, err error) {
+	h.stateLock.Lock()
+	defer h.stateLock.Unlock()
+
+	h.completedAt = completedAt
+	h.exitResult.ExitCode = exitCode
+	if oomKilled {
+		h.exitResult.OOMKilled = true
+		h.exitResult.Err = fmt.Errorf("podman container killed by OOM killer")
+		h.logger.Error("podman container killed by OOM killer", "container", h.containerID)
+	} else if err != nil {
+		h.exitResult.Err = err
+	}
+	h.procState = drivers.TaskStateExited
+}
+
 func (h *TaskHandle) runContainerMonitor() {
 	containerStatsBroadcaster := h.podmanClient.GetStatsBroadcaster()
 	if containerStatsBroadcaster == nil {
@@ -199,25 +431,102 @@ func (h *TaskHandle) runContainerMonitor() {
 
 	statsChan, errChan := containerStatsBroadcaster.Subscribe()
 
+	// Prefer the events stream for exit/OOM detection: it is authoritative
+	// and removes the race window between losing stats and confirming the
+	// exit via inspect. Fall back to the stats-error-triggered inspect path
+	// below when no events broadcaster is available.
+	var eventsChan <-chan *api.ContainerEvent
+	var eventsErrChan <-chan error
+	containerEventsBroadcaster := h.podmanClient.GetEventsBroadcaster()
+	if containerEventsBroadcaster != nil {
+		eventsChan, eventsErrChan = containerEventsBroadcaster.Subscribe()
+	} else {
+		h.logger.Debug("No container events broadcaster available, falling back to stats-based exit detection", "container", h.containerID)
+	}
+
+	// Podman emits "oom" before "die" for an OOM-killed container; "oom"
+	// carries no exit code, so remember that the kill was OOM-triggered
+	// and keep waiting for "die" to supply the real exit code instead of
+	// returning early with a hardcoded one.
+	oomKilled := false
+
+	// runContainerMonitor is started once per task, so piggyback the
+	// healthcheck watcher's startup here too rather than adding a second
+	// entry point the task lifecycle has to remember to call.
+	go h.runHealthcheckWatcher(h.driver.ctx)
+
 	for {
 		select {
 		case <-h.driver.ctx.Done():
 			return
 
+		case event := <-eventsChan:
+			if event == nil || event.Actor.ID != h.containerID {
+				continue
+			}
+			switch event.Action {
+			case "oom":
+				oomKilled = true
+			case "die":
+				exitCode, err := api.ContainerEventExitCode(event)
+				if err != nil {
+					h.logger.Warn("Unable to parse exit code from die event, falling back to inspect", "container", h.containerID, "error", err)
+					inspectData, inspectErr := h.podmanClient.ContainerInspect(h.driver.ctx, h.containerID)
+					if inspectErr != nil {
+						h.recordExit(0, oomKilled, time.Unix(event.Time, 0), fmt.Errorf("Driver was unable to get the exit code. %s: %w", h.containerID, inspectErr))
+						return
+					}
+					var exitErr error
+					if len(inspectData.State.Error) > 0 {
+						exitErr = errors.New(inspectData.State.Error)
+					}
+					h.recordExit(int(inspectData.State.ExitCode), oomKilled || inspectData.State.OOMKilled, inspectData.State.FinishedAt, exitErr)
+					return
+				}
+				h.recordExit(exitCode, oomKilled, time.Unix(event.Time, 0), nil)
+				return
+			}
+
+		case err := <-eventsErrChan:
+			h.logger.Warn("Error from container events broadcaster", "error", err)
+
 		case allStats := <-statsChan:
+			now := time.Now()
 			for _, stats := range allStats {
 				if stats.ContainerID == h.containerID {
 					h.stateLock.Lock()
 					// keep last known containerStats in handle to
-					// have it available in the stats emitter
+					// have it available in the stats emitter, along
+					// with the previous sample (and when it arrived)
+					// so per-interval rates can be derived from the
+					// monotonic counters regardless of how often the
+					// collector actually ticks
+					h.prevContainerStats = h.containerStats
+					h.prevContainerStatsSampledAt = h.containerStatsSampledAt
 					h.containerStats = *stats
+					h.containerStatsSampledAt = now
 					h.stateLock.Unlock()
+					if stats.OOMKilled {
+						// The cgroupv2 collector derives this from
+						// memory.events' oom_kill counter when no events
+						// broadcaster is running to supply an "oom"
+						// event directly; remember it the same way so
+						// whichever exit path fires next reports it.
+						oomKilled = true
+					}
 					break
 				}
 			}
 		case err := <-errChan:
 			h.logger.Warn("Error from container stats broadcaster", "error", err)
 
+			if containerEventsBroadcaster != nil {
+				// The events stream is authoritative when it is running;
+				// let it resolve exit/OOM status instead of racing it
+				// with this older, less precise inspect-based fallback.
+				continue
+			}
+
 			// Container stream has error
 			// Check if container is still running by calling the stats directly
 			_, statsErr := h.podmanClient.ContainerStats(h.driver.ctx, h.containerID)
@@ -232,28 +541,18 @@ func (h *TaskHandle) runContainerMonitor() {
 					h.logger.Debug("Container is not running anymore", "container", h.containerID, "error", statsErr)
 					// container was stopped, get exit code and other post mortem infos
 					inspectData, err := h.podmanClient.ContainerInspect(h.driver.ctx, h.containerID)
-					h.stateLock.Lock()
-					h.completedAt = time.Now()
 					if err != nil {
-						h.exitResult.Err = fmt.Errorf("Driver was unable to get the exit code. %s: %w", h.containerID, err)
 						h.logger.Error("Failed to inspect stopped container, can not get exit code", "container", h.containerID, "error", err)
-						h.exitResult.Signal = 0
-					} else {
-						h.exitResult.ExitCode = int(inspectData.State.ExitCode)
-						if len(inspectData.State.Error) > 0 {
-							h.exitResult.Err = errors.New(inspectData.State.Error)
-							h.logger.Error("Container error", "container", h.containerID, "error", h.exitResult.Err)
-						}
-						h.completedAt = inspectData.State.FinishedAt
-						if inspectData.State.OOMKilled {
-							h.exitResult.OOMKilled = true
-							h.exitResult.Err = fmt.Errorf("podman container killed by OOM killer")
-							h.logger.Error("podman container killed by OOM killer", "container", h.containerID)
-						}
+						h.recordExit(0, oomKilled, time.Now(), fmt.Errorf("Driver was unable to get the exit code. %s: %w", h.containerID, err))
+						return
 					}
 
-					h.procState = drivers.TaskStateExited
-					h.stateLock.Unlock()
+					var exitErr error
+					if len(inspectData.State.Error) > 0 {
+						exitErr = errors.New(inspectData.State.Error)
+						h.logger.Error("Container error", "container", h.containerID, "error", exitErr)
+					}
+					h.recordExit(int(inspectData.State.ExitCode), oomKilled || inspectData.State.OOMKilled, inspectData.State.FinishedAt, exitErr)
 					return
 				}
 
@@ -263,3 +562,76 @@ func (h *TaskHandle) runContainerMonitor() {
 		}
 	}
 }
+
+// healthcheckPollInterval is the cadence at which runHealthcheckWatcher
+// polls ContainerInspect, whether or not an events broadcaster is also
+// pushing health_status transitions.
+const healthcheckPollInterval = 10 * time.Second
+
+// runHealthcheckWatcher tracks the task's Podman healthcheck status. It
+// refreshes immediately on a health_status event when the events
+// broadcaster is available, and in every case also polls ContainerInspect
+// on a timer. On a transition into "unhealthy" it emits a
+// TaskEvent so operators can alert or restart on it.
+func (h *TaskHandle) runHealthcheckWatcher(ctx context.Context) {
+	h.logger.Debug("Starting healthcheckWatcher", "container", h.containerID)
+	defer h.logger.Debug("Stopping healthcheckWatcher", "container", h.containerID)
+
+	var eventsChan <-chan *api.ContainerEvent
+	var eventsErrChan <-chan error
+	if broadcaster := h.podmanClient.GetEventsBroadcaster(); broadcaster != nil {
+		eventsChan, eventsErrChan = broadcaster.Subscribe()
+	} else {
+		h.logger.Debug("No container events broadcaster available, polling for health status", "container", h.containerID)
+	}
+
+	timer := time.NewTimer(healthcheckPollInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-eventsChan:
+			if event == nil || event.Actor.ID != h.containerID || event.Action != "health_status" {
+				continue
+			}
+			h.refreshHealth(ctx)
+		case err := <-eventsErrChan:
+			h.logger.Warn("Error from container events broadcaster, falling back to health polling", "error", err)
+		case <-timer.C:
+			timer.Reset(healthcheckPollInterval)
+			h.refreshHealth(ctx)
+		}
+	}
+}
+
+// refreshHealth inspects the container, stores the latest health status
+// under stateLock, and emits a TaskEvent each time the container is
+// observed transitioning into unhealthy from any other status.
+func (h *TaskHandle) refreshHealth(ctx context.Context) {
+	inspectData, err := h.podmanClient.ContainerInspect(ctx, h.containerID)
+	if err != nil {
+		h.logger.Warn("Unable to inspect container for health status", "container", h.containerID, "error", err)
+		return
+	}
+
+	h.stateLock.Lock()
+	previousStatus := h.health.Status
+	h.health = inspectData.State.Health
+	health := h.health
+	h.stateLock.Unlock()
+
+	if health.Status == "unhealthy" && previousStatus != "unhealthy" {
+		h.logger.Warn("Container became unhealthy", "container", h.containerID, "failing_streak", health.FailingStreak)
+		h.driver.EmitEvent(&drivers.TaskEvent{
+			TaskID:    h.taskConfig.ID,
+			Timestamp: time.Now(),
+			Message:   "container became unhealthy",
+			Annotations: map[string]string{
+				"health_status":  health.Status,
+				"failing_streak": fmt.Sprintf("%d", health.FailingStreak),
+			},
+		})
+	}
+}
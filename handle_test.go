@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPumpJournalEntries(t *testing.T) {
+	input := `{"MESSAGE":"hello stdout","PRIORITY":"6","__CURSOR":"c1"}
+{"MESSAGE":"oops stderr","PRIORITY":"3","__CURSOR":"c2"}
+{"MESSAGE":"more stdout","PRIORITY":"6","__CURSOR":"c3"}
+`
+	h := &TaskHandle{}
+	var stdout, stderr bytes.Buffer
+
+	cursor, err := h.pumpJournalEntries(strings.NewReader(input), &stdout, &stderr, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cursor != "c3" {
+		t.Errorf("cursor = %q, want %q", cursor, "c3")
+	}
+	if stdout.String() != "hello stdout\nmore stdout\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if stderr.String() != "oops stderr\n" {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestPumpJournalEntriesEOFReturnsLastCursor(t *testing.T) {
+	h := &TaskHandle{}
+	var stdout, stderr bytes.Buffer
+
+	cursor, err := h.pumpJournalEntries(strings.NewReader(""), &stdout, &stderr, "seed-cursor")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cursor != "seed-cursor" {
+		t.Errorf("cursor = %q, want unchanged %q", cursor, "seed-cursor")
+	}
+}
+
+func TestPumpJournalEntriesDecodeError(t *testing.T) {
+	h := &TaskHandle{}
+	var stdout, stderr bytes.Buffer
+
+	_, err := h.pumpJournalEntries(strings.NewReader("not json"), &stdout, &stderr, "")
+	if err == nil {
+		t.Fatalf("expected decode error, got none")
+	}
+}